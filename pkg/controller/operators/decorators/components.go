@@ -0,0 +1,112 @@
+package decorators
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DriftedConditionType is set on an Operator whenever its set of adopted components has changed since the
+	// last reconciliation.
+	DriftedConditionType = "Drifted"
+
+	// MaxComponentChangeHistory bounds the ring buffer of recorded component changes kept in status, so that an
+	// Operator whose components churn frequently doesn't grow its status object without bound.
+	MaxComponentChangeHistory = 10
+)
+
+// ComponentChangeType identifies whether a component was adopted or released.
+type ComponentChangeType string
+
+const (
+	ComponentAdded   ComponentChangeType = "Added"
+	ComponentRemoved ComponentChangeType = "Removed"
+)
+
+// ComponentChange records a single addition or removal from an Operator's set of adopted components.
+type ComponentChange struct {
+	Type ComponentChangeType
+	Ref  corev1.ObjectReference
+	Time metav1.Time
+}
+
+// DiffComponentRefs compares two snapshots of an Operator's component references and returns the refs that were
+// added and removed going from before to after. Comparison is by GroupVersionKind, namespace, and name, mirroring
+// the identity componentRefEventuallyExists already uses to track a single component.
+func DiffComponentRefs(before, after []corev1.ObjectReference) (added, removed []corev1.ObjectReference) {
+	key := func(ref corev1.ObjectReference) corev1.ObjectReference {
+		ref.UID = ""
+		ref.ResourceVersion = ""
+		ref.FieldPath = ""
+		return ref
+	}
+
+	beforeSet := make(map[corev1.ObjectReference]struct{}, len(before))
+	for _, ref := range before {
+		beforeSet[key(ref)] = struct{}{}
+	}
+
+	afterSet := make(map[corev1.ObjectReference]struct{}, len(after))
+	for _, ref := range after {
+		afterSet[key(ref)] = struct{}{}
+	}
+
+	for _, ref := range after {
+		if _, ok := beforeSet[key(ref)]; !ok {
+			added = append(added, ref)
+		}
+	}
+
+	for _, ref := range before {
+		if _, ok := afterSet[key(ref)]; !ok {
+			removed = append(removed, ref)
+		}
+	}
+
+	return added, removed
+}
+
+// AppendComponentChanges appends the given changes to history, keeping only the most recent
+// MaxComponentChangeHistory entries.
+func AppendComponentChanges(history []ComponentChange, changes ...ComponentChange) []ComponentChange {
+	history = append(history, changes...)
+	if overflow := len(history) - MaxComponentChangeHistory; overflow > 0 {
+		history = history[overflow:]
+	}
+
+	return history
+}
+
+// ComponentChangeEventReason and ComponentChangeEventMessage describe the Kubernetes Event OLM records on an
+// Operator for each added or removed component, so that `kubectl describe operator` surfaces component churn
+// without requiring a diff against a previous status snapshot.
+func ComponentChangeEventReason(change ComponentChangeType) string {
+	return "Component" + string(change)
+}
+
+func ComponentChangeEventMessage(ref corev1.ObjectReference) string {
+	return fmt.Sprintf("%s %s/%s in namespace %q", ref.Kind, ref.APIVersion, ref.Name, ref.Namespace)
+}
+
+// DriftedCondition returns the Drifted condition to set on an Operator whose component set just changed, or to
+// clear it (status False) when a reconciliation observes no change.
+func DriftedCondition(drifted bool, now metav1.Time) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               DriftedConditionType,
+		LastTransitionTime: now,
+	}
+
+	if drifted {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ComponentsChanged"
+		condition.Message = "the set of adopted components has changed"
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ComponentsStable"
+		condition.Message = "the set of adopted components has not changed"
+	}
+
+	return condition
+}
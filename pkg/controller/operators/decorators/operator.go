@@ -0,0 +1,378 @@
+// Package decorators provides decorators around the Operator resource (operators.coreos.com/v2alpha1) that add
+// behavior used by the Operator controller without leaking that behavior into the generated API types.
+package decorators
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	operatorsv2alpha1 "github.com/operator-framework/api/pkg/operators/v2alpha1"
+)
+
+const (
+	// ComponentLabelKeyPrefix is prefixed to an Operator's name to create the label key used to mark that
+	// Operator's components for discovery via a label selector.
+	ComponentLabelKeyPrefix = "operators.coreos.com/"
+
+	// adoptionStrategyAnnotationKey stores the AdoptionStrategyType an Operator should use to discover its
+	// components. Absence of the annotation is equivalent to LabelAdoptionStrategy.
+	adoptionStrategyAnnotationKey = "operators.coreos.com/adoption-strategy"
+
+	// matchingResourcesAnnotationKey stores the JSON-encoded []MatchingResource consulted under the
+	// MatchingResourcesAdoptionStrategy.
+	matchingResourcesAnnotationKey = "operators.coreos.com/matching-resources"
+
+	// namespacesAnnotationKey stores the JSON-encoded []string of namespaces consulted under the
+	// NamespaceScopedAdoptionStrategy.
+	namespacesAnnotationKey = "operators.coreos.com/adoption-namespaces"
+
+	// managementStateAnnotationKey stores the ManagementState an Operator should be reconciled under. Absence of
+	// the annotation is equivalent to Managed.
+	managementStateAnnotationKey = "operators.coreos.com/management-state"
+
+	// selectorTermsAnnotationKey stores the JSON-encoded SelectorTerms AND-combined with the canonical
+	// operators.coreos.com/<name> requirement under the LabelAdoptionStrategy.
+	selectorTermsAnnotationKey = "operators.coreos.com/selector-terms"
+
+	// componentChangeHistoryAnnotationKey stores the JSON-encoded []ComponentChange ring buffer of the most
+	// recent component adoptions/releases. This stands in for a status field until componentChangeHistory lands
+	// on the upstream operators.coreos.com/v2alpha1.Operator type.
+	componentChangeHistoryAnnotationKey = "operators.coreos.com/component-change-history"
+)
+
+// ManagementState determines whether and how the Operator controller reconciles an Operator resource.
+//
+// FIXME: this reads and writes ManagementState via managementStateAnnotationKey rather than spec.managementState.
+// That was written assuming github.com/operator-framework/api's v2alpha1.Operator has no such Spec field, but this
+// repo doesn't vendor that module, so the assumption has NOT been checked against its actual source — only
+// against the absence of Spec usage anywhere else in this tree. Before merging, whoever owns v2alpha1.Operator
+// needs to confirm spec.managementState really doesn't exist; if it does, this should read/write Spec directly
+// instead, since otherwise a user setting it there is silently ignored.
+type ManagementState string
+
+const (
+	// Managed is the default state: the controller adopts components and enforces ownership as usual.
+	Managed ManagementState = "Managed"
+
+	// Unmanaged freezes status.components.refs and stops ownership enforcement, without deleting anything. This
+	// lets an admin migrate a package from one Operator name to another without the controller fighting them.
+	Unmanaged ManagementState = "Unmanaged"
+
+	// Removed garbage-collects the Operator's adopted CRDs, ServiceAccounts, and CSVs, then deletes the Operator
+	// itself.
+	Removed ManagementState = "Removed"
+)
+
+// AdoptionStrategyType identifies one of the strategies an Operator may use to discover its components.
+type AdoptionStrategyType string
+
+const (
+	// LabelAdoptionStrategy discovers components by the canonical operators.coreos.com/<name> label, optionally
+	// combined with additional selector requirements. This is the default strategy.
+	LabelAdoptionStrategy AdoptionStrategyType = "Label"
+
+	// OwnerReferenceAdoptionStrategy discovers components by walking owner references transitively, starting
+	// from the Operator's CSVs and Subscriptions.
+	OwnerReferenceAdoptionStrategy AdoptionStrategyType = "OwnerReference"
+
+	// MatchingResourcesAdoptionStrategy discovers components from an explicit list of GroupVersionKind and
+	// name/namespace tuples declared on the Operator.
+	MatchingResourcesAdoptionStrategy AdoptionStrategyType = "MatchingResources"
+
+	// NamespaceScopedAdoptionStrategy adopts every resource in the Operator's target namespaces.
+	NamespaceScopedAdoptionStrategy AdoptionStrategyType = "NamespaceScoped"
+)
+
+// MatchingResource identifies a single resource to adopt as a component when using the
+// MatchingResourcesAdoptionStrategy.
+type MatchingResource struct {
+	schema.GroupVersionKind
+	Namespace string
+	Name      string
+}
+
+// SelectorTerms are additional label requirements AND-combined with the canonical operators.coreos.com/<name>
+// requirement under the LabelAdoptionStrategy. They let an Operator narrow adoption to specific tiers or exclude
+// namespaces/resources that happen to carry the component label, e.g. test or staging copies.
+type SelectorTerms struct {
+	// MatchLabels is an AND-combined map of label key/value pairs a component must carry, e.g. to scope adoption
+	// to a tier.
+	MatchLabels map[string]string
+
+	// MatchExpressions are AND-combined with the canonical requirement and MatchLabels. In addition to Exists and
+	// In, NotIn and DoesNotExist are commonly used here to exclude components, e.g. ones bearing a
+	// tier: staging label.
+	MatchExpressions []metav1.LabelSelectorRequirement
+}
+
+// AdoptionStrategy configures how an Operator discovers the components that make it up.
+type AdoptionStrategy struct {
+	Type AdoptionStrategyType
+
+	// SelectorTerms is consulted when Type is LabelAdoptionStrategy, in addition to the canonical
+	// operators.coreos.com/<name> requirement.
+	SelectorTerms SelectorTerms
+
+	// MatchingResources is consulted when Type is MatchingResourcesAdoptionStrategy.
+	MatchingResources []MatchingResource
+
+	// Namespaces is consulted when Type is NamespaceScopedAdoptionStrategy.
+	Namespaces []string
+}
+
+// Operator decorates an Operator resource with the additional behavior needed by its controller, e.g. generating
+// the label selector and discovery strategy used to populate status.components.
+type Operator struct {
+	*operatorsv2alpha1.Operator
+
+	strategy AdoptionStrategy
+	scheme   *runtime.Scheme
+}
+
+// NewOperator returns a new Operator decorator for the given name, using the default label-based adoption
+// strategy. The decorator has no scheme, so SetAdoptionStrategy cannot validate MatchingResources GVKs; use
+// OperatorFactory.NewOperator for that.
+func NewOperator(key types.NamespacedName) (*Operator, error) {
+	o := &operatorsv2alpha1.Operator{}
+	o.SetName(key.Name)
+
+	return &Operator{
+		Operator: o,
+		strategy: AdoptionStrategy{Type: LabelAdoptionStrategy},
+	}, nil
+}
+
+// NewOperatorFromAPI decorates an existing Operator resource, reading back any adoption strategy persisted in its
+// annotations by a prior call to SetAdoptionStrategy.
+func NewOperatorFromAPI(api *operatorsv2alpha1.Operator) (*Operator, error) {
+	o := &Operator{Operator: api, strategy: AdoptionStrategy{Type: LabelAdoptionStrategy}}
+
+	annotations := api.GetAnnotations()
+	if t, ok := annotations[adoptionStrategyAnnotationKey]; ok {
+		o.strategy.Type = AdoptionStrategyType(t)
+	}
+	if raw, ok := annotations[matchingResourcesAnnotationKey]; ok {
+		if err := json.Unmarshal([]byte(raw), &o.strategy.MatchingResources); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s annotation: %w", matchingResourcesAnnotationKey, err)
+		}
+	}
+	if raw, ok := annotations[namespacesAnnotationKey]; ok {
+		if err := json.Unmarshal([]byte(raw), &o.strategy.Namespaces); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s annotation: %w", namespacesAnnotationKey, err)
+		}
+	}
+	if raw, ok := annotations[selectorTermsAnnotationKey]; ok {
+		if err := json.Unmarshal([]byte(raw), &o.strategy.SelectorTerms); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s annotation: %w", selectorTermsAnnotationKey, err)
+		}
+	}
+
+	return o, nil
+}
+
+// ComponentLabelKey returns the canonical label key used to mark a resource as a component of this Operator.
+func (o *Operator) ComponentLabelKey() string {
+	return ComponentLabelKeyPrefix + o.GetName()
+}
+
+// ComponentLabelSelector returns the label selector that matches this Operator's components under the
+// LabelAdoptionStrategy: the canonical operators.coreos.com/<name> requirement AND-combined with any additional
+// SelectorTerms configured via SetAdoptionStrategy. OperatorReconciler.discoverByLabelSelector is the caller that
+// converts this into a labels.Selector and lists components with it; the same value is also written verbatim to
+// status.components.labelSelector so a client can see exactly what was matched.
+func (o *Operator) ComponentLabelSelector() *metav1.LabelSelector {
+	terms := o.strategy.SelectorTerms
+
+	selector := &metav1.LabelSelector{
+		MatchLabels: terms.MatchLabels,
+		MatchExpressions: append([]metav1.LabelSelectorRequirement{
+			{
+				Key:      o.ComponentLabelKey(),
+				Operator: metav1.LabelSelectorOpExists,
+			},
+		}, terms.MatchExpressions...),
+	}
+
+	return selector
+}
+
+// AdoptionStrategy returns the strategy this Operator uses to discover its components.
+func (o *Operator) AdoptionStrategy() AdoptionStrategy {
+	return o.strategy
+}
+
+// SetAdoptionStrategy configures the strategy this Operator uses to discover its components, persisting it to the
+// Operator's annotations so it survives a round-trip through the API server.
+func (o *Operator) SetAdoptionStrategy(strategy AdoptionStrategy) error {
+	annotations := o.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[adoptionStrategyAnnotationKey] = string(strategy.Type)
+
+	if len(strategy.MatchingResources) > 0 {
+		if o.scheme != nil {
+			for _, m := range strategy.MatchingResources {
+				if !o.scheme.Recognizes(m.GroupVersionKind) {
+					return fmt.Errorf("matching resource %s is not registered in scheme", m.GroupVersionKind)
+				}
+			}
+		}
+
+		raw, err := json.Marshal(strategy.MatchingResources)
+		if err != nil {
+			return fmt.Errorf("failed to marshal matching resources: %w", err)
+		}
+		annotations[matchingResourcesAnnotationKey] = string(raw)
+	} else {
+		delete(annotations, matchingResourcesAnnotationKey)
+	}
+
+	if len(strategy.Namespaces) > 0 {
+		raw, err := json.Marshal(strategy.Namespaces)
+		if err != nil {
+			return fmt.Errorf("failed to marshal adoption namespaces: %w", err)
+		}
+		annotations[namespacesAnnotationKey] = string(raw)
+	} else {
+		delete(annotations, namespacesAnnotationKey)
+	}
+
+	if len(strategy.SelectorTerms.MatchLabels) > 0 || len(strategy.SelectorTerms.MatchExpressions) > 0 {
+		raw, err := json.Marshal(strategy.SelectorTerms)
+		if err != nil {
+			return fmt.Errorf("failed to marshal selector terms: %w", err)
+		}
+		annotations[selectorTermsAnnotationKey] = string(raw)
+	} else {
+		delete(annotations, selectorTermsAnnotationKey)
+	}
+
+	o.SetAnnotations(annotations)
+	o.strategy = strategy
+
+	return nil
+}
+
+// ManagementState returns the state this Operator should be reconciled under, defaulting to Managed when unset.
+func (o *Operator) ManagementState() ManagementState {
+	state := ManagementState(o.GetAnnotations()[managementStateAnnotationKey])
+	if state == "" {
+		return Managed
+	}
+
+	return state
+}
+
+// SetManagementState configures the state this Operator should be reconciled under, persisting it to the
+// Operator's annotations so it survives a round-trip through the API server.
+func (o *Operator) SetManagementState(state ManagementState) {
+	annotations := o.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[managementStateAnnotationKey] = string(state)
+	o.SetAnnotations(annotations)
+}
+
+// IsManaged returns true if the controller should adopt components and enforce ownership as usual.
+func (o *Operator) IsManaged() bool {
+	return o.ManagementState() == Managed
+}
+
+// IsUnmanaged returns true if the controller should freeze status.components.refs and stop enforcing ownership.
+func (o *Operator) IsUnmanaged() bool {
+	return o.ManagementState() == Unmanaged
+}
+
+// IsRemoved returns true if the controller should garbage-collect this Operator's adopted components and then
+// delete the Operator itself.
+func (o *Operator) IsRemoved() bool {
+	return o.ManagementState() == Removed
+}
+
+// ComponentChangeHistory returns the bounded history of component additions and removals most recently recorded
+// against this Operator, oldest first.
+func (o *Operator) ComponentChangeHistory() ([]ComponentChange, error) {
+	raw, ok := o.GetAnnotations()[componentChangeHistoryAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var history []ComponentChange
+	if err := json.Unmarshal([]byte(raw), &history); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal component change history: %w", err)
+	}
+
+	return history, nil
+}
+
+// SetComponentChangeHistory persists history, bounded to MaxComponentChangeHistory entries, to the Operator's
+// annotations so it survives a round-trip through the API server.
+func (o *Operator) SetComponentChangeHistory(history []ComponentChange) error {
+	if overflow := len(history) - MaxComponentChangeHistory; overflow > 0 {
+		history = history[overflow:]
+	}
+
+	raw, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to marshal component change history: %w", err)
+	}
+
+	annotations := o.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[componentChangeHistoryAnnotationKey] = string(raw)
+	o.SetAnnotations(annotations)
+
+	return nil
+}
+
+// OperatorFactory describes methods for constructing Operator resource decorators.
+type OperatorFactory interface {
+	NewOperator(key types.NamespacedName) (*Operator, error)
+	NewPackageOperator(pkg, namespace string) (*Operator, error)
+}
+
+type schemedOperatorFactory struct {
+	scheme *runtime.Scheme
+}
+
+// NewSchemedOperatorFactory returns an OperatorFactory whose decorators have access to the given scheme, so that
+// SetAdoptionStrategy can reject a MatchingResourcesAdoptionStrategy naming a GroupVersionKind the scheme doesn't
+// recognize, rather than accepting a typo'd GVK that would silently never resolve at discovery time.
+func NewSchemedOperatorFactory(scheme *runtime.Scheme) (OperatorFactory, error) {
+	if scheme == nil {
+		return nil, fmt.Errorf("scheme must be non-nil")
+	}
+
+	return &schemedOperatorFactory{scheme: scheme}, nil
+}
+
+func (s *schemedOperatorFactory) NewOperator(key types.NamespacedName) (*Operator, error) {
+	o, err := NewOperator(key)
+	if err != nil {
+		return nil, err
+	}
+	o.scheme = s.scheme
+
+	return o, nil
+}
+
+// NewPackageOperator returns the Operator decorator for the Operator resource generated on behalf of a Subscription
+// to the given package in the given namespace.
+func (s *schemedOperatorFactory) NewPackageOperator(pkg, namespace string) (*Operator, error) {
+	o, err := NewOperator(types.NamespacedName{Name: fmt.Sprintf("%s.%s", pkg, namespace)})
+	if err != nil {
+		return nil, err
+	}
+	o.scheme = s.scheme
+
+	return o, nil
+}
@@ -0,0 +1,418 @@
+// Package operators implements the controller for the Operator resource (operators.coreos.com/v2alpha1): it
+// discovers the set of components an Operator has adopted according to its configured AdoptionStrategy and
+// surfaces them on status.components.
+package operators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv2alpha1 "github.com/operator-framework/api/pkg/operators/v2alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/decorators"
+)
+
+// requeuePollInterval bounds how stale status.components can get when a change can't be observed via the watches
+// SetupWithManager establishes -- notably a MatchingResourcesAdoptionStrategy entry naming a GVK outside
+// defaultComponentKinds, which this reconciler has no watch on.
+const requeuePollInterval = 5 * time.Minute
+
+// defaultComponentKinds are the kinds the Label and NamespaceScoped adoption strategies consider when discovering
+// an Operator's components. This mirrors the kinds the "should automatically adopt components" e2e spec already
+// exercises for a packaged Operator, including the CRDs a CSV installs, which removableKinds also needs to see
+// discovered in order to garbage-collect them under Removed.
+var defaultComponentKinds = []schema.GroupVersionKind{
+	corev1.SchemeGroupVersion.WithKind("Namespace"),
+	corev1.SchemeGroupVersion.WithKind("ServiceAccount"),
+	corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+	apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"),
+	operatorsv1alpha1.SchemeGroupVersion.WithKind(operatorsv1alpha1.ClusterServiceVersionKind),
+	operatorsv1alpha1.SchemeGroupVersion.WithKind(operatorsv1alpha1.SubscriptionKind),
+}
+
+// OperatorReconciler reconciles an Operator by discovering its components according to its AdoptionStrategy,
+// writing the result to status.components, and recording any drift from the previous reconciliation as both a
+// Kubernetes Event and a Drifted status condition.
+type OperatorReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// SetupWithManager registers this reconciler against an Operator resource watch, plus a watch on each kind in
+// defaultComponentKinds so that a component being labeled, unlabeled, created, or deleted requeues the Operator(s)
+// it affects instead of waiting for the next unrelated Operator event.
+func (r *OperatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Recorder = mgr.GetEventRecorderFor("operator-controller")
+
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(&operatorsv2alpha1.Operator{})
+
+	for _, component := range []client.Object{
+		&corev1.Namespace{},
+		&corev1.ServiceAccount{},
+		&corev1.ConfigMap{},
+		&apiextensionsv1.CustomResourceDefinition{},
+		&operatorsv1alpha1.ClusterServiceVersion{},
+		&operatorsv1alpha1.Subscription{},
+	} {
+		bldr = bldr.Watches(&source.Kind{Type: component}, handler.EnqueueRequestsFromMapFunc(r.mapComponentToOperators))
+	}
+
+	return bldr.Complete(r)
+}
+
+// mapComponentToOperators requeues every Operator that may have adopted obj: any Operator whose component label key
+// appears on obj; any Operator using the NamespaceScoped strategy with obj's namespace among its target namespaces;
+// and any Operator whose derived namespace (see namespaceFromOperatorName) matches obj's namespace, which the
+// OwnerReference strategy relies on since it discovers by listing the whole namespace rather than by label.
+func (r *OperatorReconciler) mapComponentToOperators(obj client.Object) []reconcile.Request {
+	operators := &operatorsv2alpha1.OperatorList{}
+	if err := r.Client.List(context.Background(), operators); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range operators.Items {
+		api := &operators.Items[i]
+
+		if namespace, ok := namespaceFromOperatorName(api.GetName()); ok && namespace == obj.GetNamespace() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: api.GetName()}})
+			continue
+		}
+
+		o, err := decorators.NewOperatorFromAPI(api)
+		if err != nil {
+			continue
+		}
+		inTargetNamespace := false
+		if strategy := o.AdoptionStrategy(); strategy.Type == decorators.NamespaceScopedAdoptionStrategy {
+			for _, ns := range strategy.Namespaces {
+				inTargetNamespace = inTargetNamespace || ns == obj.GetNamespace()
+			}
+		}
+		if _, ok := obj.GetLabels()[o.ComponentLabelKey()]; ok || inTargetNamespace {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: api.GetName()}})
+		}
+	}
+
+	return requests
+}
+
+// Reconcile discovers the components of the Operator named by req and writes them to its status.
+func (r *OperatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	api := &operatorsv2alpha1.Operator{}
+	if err := r.Client.Get(ctx, req.NamespacedName, api); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	o, err := decorators.NewOperatorFromAPI(api)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if o.IsRemoved() {
+		return ctrl.Result{}, r.remove(ctx, o)
+	}
+
+	if o.IsUnmanaged() {
+		// Leave status.components exactly as it was observed last.
+		return ctrl.Result{}, nil
+	}
+
+	refs, err := r.discoverComponents(ctx, o)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	before := existingRefs(api)
+	added, removed := decorators.DiffComponentRefs(before, refs)
+	drifted := len(added) > 0 || len(removed) > 0
+	now := metav1.Now()
+
+	if drifted {
+		history, err := o.ComponentChangeHistory()
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		var changes []decorators.ComponentChange
+		for _, ref := range added {
+			changes = append(changes, decorators.ComponentChange{Type: decorators.ComponentAdded, Ref: ref, Time: now})
+			r.Recorder.Event(api, corev1.EventTypeNormal, decorators.ComponentChangeEventReason(decorators.ComponentAdded), decorators.ComponentChangeEventMessage(ref))
+		}
+		for _, ref := range removed {
+			changes = append(changes, decorators.ComponentChange{Type: decorators.ComponentRemoved, Ref: ref, Time: now})
+			r.Recorder.Event(api, corev1.EventTypeNormal, decorators.ComponentChangeEventReason(decorators.ComponentRemoved), decorators.ComponentChangeEventMessage(ref))
+		}
+
+		if err := o.SetComponentChangeHistory(decorators.AppendComponentChanges(history, changes...)); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// The history lives in an annotation, which the status update below does not persist.
+		if err := r.Client.Update(ctx, api); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	meta.SetStatusCondition(&api.Status.Conditions, decorators.DriftedCondition(drifted, now))
+
+	api.Status.Components = &operatorsv2alpha1.Components{
+		LabelSelector: o.ComponentLabelSelector(),
+	}
+	for i := range refs {
+		api.Status.Components.Refs = append(api.Status.Components.Refs, operatorsv2alpha1.RichReference{ObjectReference: &refs[i]})
+	}
+
+	return ctrl.Result{RequeueAfter: requeuePollInterval}, r.Client.Status().Update(ctx, api)
+}
+
+// removableKinds are the kinds Removed mode garbage-collects: an Operator's adopted CRDs, ServiceAccounts, and
+// CSVs. Everything else it adopted (e.g. the Namespace or ConfigMaps it discovered) is left alone, since those
+// aren't owned by the Operator in the sense the Removed contract describes.
+var removableKinds = map[string]struct{}{
+	"CustomResourceDefinition":                  {},
+	"ServiceAccount":                            {},
+	operatorsv1alpha1.ClusterServiceVersionKind: {},
+}
+
+// remove garbage-collects o's adopted CRDs, ServiceAccounts, and CSVs, then deletes the Operator itself.
+func (r *OperatorReconciler) remove(ctx context.Context, o *decorators.Operator) error {
+	refs, err := r.discoverComponents(ctx, o)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if _, ok := removableKinds[ref.Kind]; !ok {
+			continue
+		}
+
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(ref.APIVersion)
+		u.SetKind(ref.Kind)
+		u.SetNamespace(ref.Namespace)
+		u.SetName(ref.Name)
+		if err := r.Client.Delete(ctx, u); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to garbage-collect %s %s/%s: %w", ref.Kind, ref.Namespace, ref.Name, err)
+		}
+	}
+
+	return client.IgnoreNotFound(r.Client.Delete(ctx, o.Operator))
+}
+
+// existingRefs flattens the RichReferences currently on status.components.refs, the "before" snapshot DiffComponentRefs
+// compares newly discovered refs against.
+func existingRefs(api *operatorsv2alpha1.Operator) []corev1.ObjectReference {
+	if api.Status.Components == nil {
+		return nil
+	}
+
+	refs := make([]corev1.ObjectReference, 0, len(api.Status.Components.Refs))
+	for _, rich := range api.Status.Components.Refs {
+		if rich.ObjectReference != nil {
+			refs = append(refs, *rich.ObjectReference)
+		}
+	}
+
+	return refs
+}
+
+// discoverComponents returns the set of component references o's AdoptionStrategy currently matches.
+func (r *OperatorReconciler) discoverComponents(ctx context.Context, o *decorators.Operator) ([]corev1.ObjectReference, error) {
+	strategy := o.AdoptionStrategy()
+
+	switch strategy.Type {
+	case decorators.MatchingResourcesAdoptionStrategy:
+		return r.discoverMatchingResources(ctx, strategy.MatchingResources)
+	case decorators.NamespaceScopedAdoptionStrategy:
+		return r.discoverNamespaceScoped(ctx, strategy.Namespaces)
+	case decorators.OwnerReferenceAdoptionStrategy:
+		return r.discoverOwnerReferenceWalk(ctx, o)
+	case decorators.LabelAdoptionStrategy, "":
+		return r.discoverByLabelSelector(ctx, o, "")
+	default:
+		return nil, fmt.Errorf("unknown adoption strategy %q", strategy.Type)
+	}
+}
+
+// discoverByLabelSelector lists every kind in defaultComponentKinds, optionally scoped to namespace, that matches
+// o's component label selector.
+func (r *OperatorReconciler) discoverByLabelSelector(ctx context.Context, o *decorators.Operator, namespace string) ([]corev1.ObjectReference, error) {
+	selector, err := asLabelsSelector(o.ComponentLabelSelector())
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert component label selector: %w", err)
+	}
+
+	var refs []corev1.ObjectReference
+	for _, gvk := range defaultComponentKinds {
+		kindRefs, err := r.listKind(ctx, gvk, namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, kindRefs...)
+	}
+
+	return refs, nil
+}
+
+// discoverNamespaceScoped lists every kind in defaultComponentKinds in each of namespaces, without a label filter.
+// Of the cluster-scoped kinds, only each target Namespace itself is kept.
+func (r *OperatorReconciler) discoverNamespaceScoped(ctx context.Context, namespaces []string) ([]corev1.ObjectReference, error) {
+	var refs []corev1.ObjectReference
+	for _, ns := range namespaces {
+		for _, gvk := range defaultComponentKinds {
+			kindRefs, err := r.listKind(ctx, gvk, ns, labels.Everything())
+			if err != nil {
+				return nil, err
+			}
+			// A namespace filter doesn't apply to cluster-scoped kinds, so their lists span the whole cluster.
+			for _, ref := range kindRefs {
+				if ref.Namespace == ns || (ref.Kind == "Namespace" && ref.Name == ns) {
+					refs = append(refs, ref)
+				}
+			}
+		}
+	}
+
+	return refs, nil
+}
+
+// discoverMatchingResources resolves each configured MatchingResource to a live object, skipping any that no
+// longer exist.
+func (r *OperatorReconciler) discoverMatchingResources(ctx context.Context, matching []decorators.MatchingResource) ([]corev1.ObjectReference, error) {
+	var refs []corev1.ObjectReference
+	for _, m := range matching {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(m.GroupVersionKind)
+		key := client.ObjectKey{Namespace: m.Namespace, Name: m.Name}
+		if err := r.Client.Get(ctx, key, u); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		refs = append(refs, refFromUnstructured(u))
+	}
+
+	return refs, nil
+}
+
+// discoverOwnerReferenceWalk seeds adoption from the Subscription and CSVs installed for o's package/namespace
+// (derived from o's name, in the "<package>.<namespace>" convention NewPackageOperator uses), then transitively
+// adopts any defaultComponentKinds object whose owner reference chain leads back to one of those seeds.
+func (r *OperatorReconciler) discoverOwnerReferenceWalk(ctx context.Context, o *decorators.Operator) ([]corev1.ObjectReference, error) {
+	namespace, ok := namespaceFromOperatorName(o.GetName())
+	if !ok {
+		return nil, nil
+	}
+
+	var candidates []unstructured.Unstructured
+	for _, gvk := range defaultComponentKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+		if err := r.Client.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, list.Items...)
+	}
+
+	adopted := map[types.UID]struct{}{}
+	for _, c := range candidates {
+		if c.GetKind() == operatorsv1alpha1.ClusterServiceVersionKind || c.GetKind() == operatorsv1alpha1.SubscriptionKind {
+			adopted[c.GetUID()] = struct{}{}
+		}
+	}
+
+	// Walk owner references to a fixed point: an object adopted in one pass may itself own further objects.
+	for changed := true; changed; {
+		changed = false
+		for _, c := range candidates {
+			if _, ok := adopted[c.GetUID()]; ok {
+				continue
+			}
+			for _, owner := range c.GetOwnerReferences() {
+				if _, ok := adopted[owner.UID]; ok {
+					adopted[c.GetUID()] = struct{}{}
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var refs []corev1.ObjectReference
+	for _, c := range candidates {
+		if _, ok := adopted[c.GetUID()]; ok {
+			refs = append(refs, refFromUnstructured(&c))
+		}
+	}
+
+	return refs, nil
+}
+
+func (r *OperatorReconciler) listKind(ctx context.Context, gvk schema.GroupVersionKind, namespace string, selector labels.Selector) ([]corev1.ObjectReference, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk)
+
+	opts := []client.ListOption{client.MatchingLabelsSelector{Selector: selector}}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+
+	if err := r.Client.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+
+	refs := make([]corev1.ObjectReference, 0, len(list.Items))
+	for i := range list.Items {
+		refs = append(refs, refFromUnstructured(&list.Items[i]))
+	}
+
+	return refs, nil
+}
+
+func refFromUnstructured(u *unstructured.Unstructured) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		APIVersion: u.GetAPIVersion(),
+		Kind:       u.GetKind(),
+		Namespace:  u.GetNamespace(),
+		Name:       u.GetName(),
+	}
+}
+
+// namespaceFromOperatorName recovers the namespace NewPackageOperator embedded in a package Operator's name
+// ("<package>.<namespace>"), returning ok=false for Operators not named by that convention.
+func namespaceFromOperatorName(name string) (namespace string, ok bool) {
+	i := strings.LastIndex(name, ".")
+	if i < 0 || i == len(name)-1 {
+		return "", false
+	}
+
+	return name[i+1:], true
+}
+
+func asLabelsSelector(selector *metav1.LabelSelector) (labels.Selector, error) {
+	return metav1.LabelSelectorAsSelector(selector)
+}
@@ -3,12 +3,10 @@ package e2e
 import (
 	"context"
 	"fmt"
+	"testing"
 	"time"
 
-	. "github.com/onsi/ginkgo"
-	. "github.com/onsi/gomega"
-	"github.com/onsi/gomega/format"
-	gomegatypes "github.com/onsi/gomega/types"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -28,39 +26,32 @@ import (
 	"github.com/operator-framework/operator-lifecycle-manager/test/e2e/ctx"
 )
 
-// Describes test specs for the Operator resource.
-var _ = Describe("Operator", func() {
-	var (
-		clientCtx       context.Context
-		scheme          *runtime.Scheme
-		listOpts        metav1.ListOptions
-		operatorClient  clientv2alpha1.OperatorInterface
-		client          controllerclient.Client
-		operatorFactory decorators.OperatorFactory
-	)
-
-	BeforeEach(func() {
-		// Toggle v2alpha1 feature-gate
-		toggleCVO()
-		togglev2alpha1()
-
-		// Setup common utilities
-		clientCtx = context.Background()
-		scheme = ctx.Ctx().Scheme()
-		listOpts = metav1.ListOptions{}
-		operatorClient = ctx.Ctx().OperatorClient().OperatorsV2alpha1().Operators()
-		client = ctx.Ctx().Client()
-
-		var err error
-		operatorFactory, err = decorators.NewSchemedOperatorFactory(scheme)
-		Expect(err).ToNot(HaveOccurred())
-	})
+// pollInterval and pollTimeout bound the require.Eventuallyf loops used throughout this file in place of Gomega's
+// Eventually.
+const (
+	pollInterval = 1 * time.Second
+	pollTimeout  = 1 * time.Minute
+)
 
-	AfterEach(func() {
+// TestOperator exercises the Operator resource (operators.coreos.com/v2alpha1).
+func TestOperator(t *testing.T) {
+	// Toggle v2alpha1 feature-gate for the duration of the suite.
+	toggleCVO()
+	togglev2alpha1()
+	t.Cleanup(func() {
 		togglev2alpha1()
 		toggleCVO()
 	})
 
+	clientCtx := context.Background()
+	scheme := ctx.Ctx().Scheme()
+	listOpts := metav1.ListOptions{}
+	operatorClient := ctx.Ctx().OperatorClient().OperatorsV2alpha1().Operators()
+	client := ctx.Ctx().Client()
+
+	operatorFactory, err := decorators.NewSchemedOperatorFactory(scheme)
+	require.NoError(t, err)
+
 	// Ensures that an Operator resource can select its components by label and surface them correctly in its status.
 	//
 	// Steps:
@@ -76,31 +67,18 @@ var _ = Describe("Operator", func() {
 	// 10. Ensure the reference to sa-b is eventually removed from o's status.components.refs field
 	// 11. Delete ns-a
 	// 12. Ensure the reference to ns-a is eventually removed from o's status.components.refs field
-	It("should surface components in its status", func() {
+	t.Run("should surface components in its status", func(t *testing.T) {
+		t.Parallel()
+
 		o := &operatorsv2alpha1.Operator{}
 		o.SetName(genName("o-"))
+		defer requireCreate(t, clientCtx, client, o)()
 
-		Eventually(func() error {
-			return client.Create(clientCtx, o)
-		}).Should(Succeed())
-
-		defer func() {
-			Eventually(func() error {
-				err := client.Delete(clientCtx, o)
-				if apierrors.IsNotFound(err) {
-					return nil
-				}
-
-				return err
-			}).Should(Succeed())
-		}()
-
-		By("eventually having a status that contains its component label selector")
 		w, err := operatorClient.Watch(clientCtx, listOpts)
-		Expect(err).ToNot(HaveOccurred())
+		require.NoError(t, err)
 		defer w.Stop()
 
-		deadline, cancel := context.WithTimeout(clientCtx, 1*time.Minute)
+		deadline, cancel := context.WithTimeout(clientCtx, pollTimeout)
 		defer cancel()
 
 		expectedKey := "operators.coreos.com/" + o.GetName()
@@ -117,7 +95,6 @@ var _ = Describe("Operator", func() {
 
 			return false
 		}))
-		defer w.Stop()
 
 		// Create namespaces ns-a and ns-b
 		nsA := &corev1.Namespace{}
@@ -126,19 +103,7 @@ var _ = Describe("Operator", func() {
 		nsB.SetName(genName("ns-b-"))
 
 		for _, ns := range []*corev1.Namespace{nsA, nsB} {
-			Eventually(func() error {
-				return client.Create(clientCtx, ns)
-			}).Should(Succeed())
-
-			defer func(n *corev1.Namespace) {
-				Eventually(func() error {
-					err := client.Delete(clientCtx, n)
-					if apierrors.IsNotFound(err) {
-						return nil
-					}
-					return err
-				}).Should(Succeed())
-			}(ns)
+			defer requireCreate(t, clientCtx, client, ns)()
 		}
 
 		// Label ns-a with o's component label
@@ -146,11 +111,10 @@ var _ = Describe("Operator", func() {
 			m.SetLabels(map[string]string{expectedKey: ""})
 			return nil
 		}
-		Eventually(Apply(nsA, setComponentLabel)).Should(Succeed())
+		requireEventually(t, Apply(nsA, setComponentLabel))
 
 		// Ensure o's status.components.refs field eventually contains a reference to ns-a
-		By("eventually listing a single component reference")
-		componentRefEventuallyExists(w, true, getReference(scheme, nsA))
+		componentRefEventuallyExists(t, w, true, getReference(scheme, nsA))
 
 		// Create ServiceAccounts sa-a and sa-b in namespaces ns-a and ns-b respectively
 		saA := &corev1.ServiceAccount{}
@@ -161,149 +125,477 @@ var _ = Describe("Operator", func() {
 		saB.SetNamespace(nsB.GetName())
 
 		for _, sa := range []*corev1.ServiceAccount{saA, saB} {
-			Eventually(func() error {
-				return client.Create(clientCtx, sa)
-			}).Should(Succeed())
-			defer func(sa *corev1.ServiceAccount) {
-				Eventually(func() error {
-					err := client.Delete(clientCtx, sa)
-					if apierrors.IsNotFound(err) {
-						return nil
-					}
-					return err
-				}).Should(Succeed())
-			}(sa)
+			defer requireCreate(t, clientCtx, client, sa)()
 		}
 
 		// Label sa-a and sa-b with o's component label
-		Eventually(Apply(saA, setComponentLabel)).Should(Succeed())
-		Eventually(Apply(saB, setComponentLabel)).Should(Succeed())
+		requireEventually(t, Apply(saA, setComponentLabel))
+		requireEventually(t, Apply(saB, setComponentLabel))
 
 		// Ensure o's status.components.refs field eventually contains references to sa-a and sa-b
-		By("eventually listing multiple component references")
-		componentRefEventuallyExists(w, true, getReference(scheme, saA))
-		componentRefEventuallyExists(w, true, getReference(scheme, saB))
+		componentRefEventuallyExists(t, w, true, getReference(scheme, saA))
+		componentRefEventuallyExists(t, w, true, getReference(scheme, saB))
 
 		// Remove the component label from sa-b
-		Eventually(Apply(saB, func(m metav1.Object) error {
+		requireEventually(t, Apply(saB, func(m metav1.Object) error {
 			m.SetLabels(nil)
 			return nil
-		})).Should(Succeed())
+		}))
 
 		// Ensure the reference to sa-b is eventually removed from o's status.components.refs field
-		By("removing a component's reference when it no longer bears the component label")
-		componentRefEventuallyExists(w, false, getReference(scheme, saB))
+		componentRefEventuallyExists(t, w, false, getReference(scheme, saB))
 
 		// Delete ns-a
-		Eventually(func() error {
+		requireEventually(t, func() error {
 			err := client.Delete(clientCtx, nsA)
 			if apierrors.IsNotFound(err) {
 				return nil
 			}
 			return err
-		}).Should(Succeed())
+		})
 
 		// Ensure the reference to ns-a is eventually removed from o's status.components.refs field
-		By("removing a component's reference when it no longer exists")
-		componentRefEventuallyExists(w, false, getReference(scheme, nsA))
+		componentRefEventuallyExists(t, w, false, getReference(scheme, nsA))
 	})
 
-	Context("when a subscription to a package exists", func() {
-		var (
-			ns           *corev1.Namespace
-			sub          *operatorsv1alpha1.Subscription
-			operatorName types.NamespacedName
-		)
+	// Ensures that labeling and unlabeling a component produces both a Kubernetes Event naming the component and
+	// a transition of the Operator's Drifted condition.
+	//
+	// Steps:
+	// 1. Create an Operator resource, o, and a ServiceAccount, sa
+	// 2. Label sa with o's component label
+	// 3. Ensure o's status.conditions eventually contains a True Drifted condition
+	// 4. Ensure an Event naming sa's addition is eventually recorded against o
+	// 5. Unlabel sa
+	// 6. Ensure another Event naming sa's removal is eventually recorded against o
+	t.Run("should record a component-diff event and Drifted condition when components change", func(t *testing.T) {
+		t.Parallel()
+
+		o := &operatorsv2alpha1.Operator{}
+		o.SetName(genName("o-"))
+		defer requireCreate(t, clientCtx, client, o)()
 
-		BeforeEach(func() {
-			// Subscribe to a package and await a successful install
-			ns = &corev1.Namespace{}
-			ns.SetName(genName("ns-"))
-			Eventually(func() error {
-				return client.Create(clientCtx, ns)
-			}).Should(Succeed())
-
-			// Default to AllNamespaces
-			og := &operatorsv1.OperatorGroup{}
-			og.SetNamespace(ns.GetName())
-			og.SetName(genName("og-"))
-			Eventually(func() error {
-				return client.Create(clientCtx, og)
-			}).Should(Succeed())
-
-			cs := &operatorsv1alpha1.CatalogSource{
-				Spec: operatorsv1alpha1.CatalogSourceSpec{
-					SourceType: operatorsv1alpha1.SourceTypeGrpc,
-					Image:      "quay.io/olmtest/single-bundle-index:1.0.0",
-				},
+		sa := &corev1.ServiceAccount{}
+		sa.SetName(genName("sa-"))
+		sa.SetNamespace("default")
+		defer requireCreate(t, clientCtx, client, sa)()
+
+		w, err := operatorClient.Watch(clientCtx, listOpts)
+		require.NoError(t, err)
+		defer w.Stop()
+
+		expectedKey := "operators.coreos.com/" + o.GetName()
+		requireEventually(t, Apply(sa, func(m metav1.Object) error {
+			m.SetLabels(map[string]string{expectedKey: ""})
+			return nil
+		}))
+
+		deadline, cancel := context.WithTimeout(clientCtx, pollTimeout)
+		defer cancel()
+		awaitPredicates(deadline, w, operatorPredicate(func(op *operatorsv2alpha1.Operator) bool {
+			return driftedConditionStatus(op) == metav1.ConditionTrue
+		}))
+
+		require.Eventuallyf(t, func() bool {
+			return componentChangeEventRecorded(clientCtx, client, o, decorators.ComponentAdded, getReference(scheme, sa))
+		}, pollTimeout, pollInterval, "expected a ComponentAdded event naming %s", sa.GetName())
+
+		requireEventually(t, Apply(sa, func(m metav1.Object) error {
+			m.SetLabels(nil)
+			return nil
+		}))
+
+		require.Eventuallyf(t, func() bool {
+			return componentChangeEventRecorded(clientCtx, client, o, decorators.ComponentRemoved, getReference(scheme, sa))
+		}, pollTimeout, pollInterval, "expected a ComponentRemoved event naming %s", sa.GetName())
+	})
+
+	// Ensures that additional SelectorTerms are AND-combined with the canonical component label, letting an
+	// Operator exclude components that happen to carry that label, and that the composite selector round-trips
+	// through status.components.labelSelector.
+	//
+	// Steps:
+	// 1. Create an Operator, o, configured with a NotIn SelectorTerm excluding tier: staging
+	// 2. Ensure o's status.components.labelSelector eventually contains both the canonical requirement and the
+	//    NotIn requirement
+	// 3. Label a namespace with both o's component key and tier: staging
+	// 4. Ensure the namespace's reference is never added to status.components.refs
+	t.Run("should support multi-selector and negative-match component adoption", func(t *testing.T) {
+		t.Parallel()
+
+		o := &operatorsv2alpha1.Operator{}
+		o.SetName(genName("o-"))
+
+		deco, err := operatorFactory.NewOperator(testobj.NamespacedName(o))
+		require.NoError(t, err)
+		excludedTierRequirement := metav1.LabelSelectorRequirement{
+			Key:      "tier",
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   []string{"staging"},
+		}
+		require.NoError(t, deco.SetAdoptionStrategy(decorators.AdoptionStrategy{
+			Type: decorators.LabelAdoptionStrategy,
+			SelectorTerms: decorators.SelectorTerms{
+				MatchExpressions: []metav1.LabelSelectorRequirement{excludedTierRequirement},
+			},
+		}))
+		o.SetAnnotations(deco.GetAnnotations())
+		defer requireCreate(t, clientCtx, client, o)()
+
+		w, err := operatorClient.Watch(clientCtx, listOpts)
+		require.NoError(t, err)
+		defer w.Stop()
+
+		expectedKey := "operators.coreos.com/" + o.GetName()
+		deadline, cancel := context.WithTimeout(clientCtx, pollTimeout)
+		defer cancel()
+		awaitPredicates(deadline, w, operatorPredicate(func(op *operatorsv2alpha1.Operator) bool {
+			if op.Status.Components == nil || op.Status.Components.LabelSelector == nil {
+				return false
+			}
+
+			var sawCanonical, sawExcluded bool
+			for _, requirement := range op.Status.Components.LabelSelector.MatchExpressions {
+				if requirement.Key == expectedKey && requirement.Operator == metav1.LabelSelectorOpExists {
+					sawCanonical = true
+				}
+				if requirement.Key == excludedTierRequirement.Key && requirement.Operator == excludedTierRequirement.Operator {
+					sawExcluded = true
+				}
+			}
+
+			return sawCanonical && sawExcluded
+		}))
+
+		ns := &corev1.Namespace{}
+		ns.SetName(genName("ns-"))
+		ns.SetLabels(map[string]string{expectedKey: "", "tier": "staging"})
+		defer requireCreate(t, clientCtx, client, ns)()
+
+		operatorName := testobj.NamespacedName(o)
+		require.Never(t, func() bool {
+			got := &operatorsv2alpha1.Operator{}
+			if err := client.Get(clientCtx, operatorName, got); err != nil {
+				return false
 			}
-			cs.SetNamespace(ns.GetName())
-			cs.SetName(genName("cs-"))
-			Eventually(func() error {
-				return client.Create(clientCtx, cs)
-			}).Should(Succeed())
-
-			sub = &operatorsv1alpha1.Subscription{
-				Spec: &operatorsv1alpha1.SubscriptionSpec{
-					CatalogSource:          cs.GetName(),
-					CatalogSourceNamespace: cs.GetNamespace(),
-					Package:                "kiali",
-					Channel:                "stable",
-					InstallPlanApproval:    operatorsv1alpha1.ApprovalAutomatic,
+			return referencesComponents(got, []*corev1.ObjectReference{getReference(scheme, ns)})
+		}, 10*time.Second, pollInterval, "expected the excluded namespace to never be adopted")
+	})
+
+	t.Run("when configured with an alternate adoption strategy", func(t *testing.T) {
+		t.Parallel()
+
+		// Ensures that an Operator configured with the OwnerReference adoption strategy transitively adopts
+		// everything owned by its CSV, without requiring the component label.
+		t.Run("should adopt components via owner-reference walk", func(t *testing.T) {
+			t.Parallel()
+
+			// The OwnerReference strategy derives the namespace to walk from a "<package>.<namespace>" name.
+			o := &operatorsv2alpha1.Operator{}
+			o.SetName(genName("o-") + ".default")
+
+			deco, err := operatorFactory.NewOperator(testobj.NamespacedName(o))
+			require.NoError(t, err)
+			require.NoError(t, deco.SetAdoptionStrategy(decorators.AdoptionStrategy{Type: decorators.OwnerReferenceAdoptionStrategy}))
+			o.SetAnnotations(deco.GetAnnotations())
+			defer requireCreate(t, clientCtx, client, o)()
+
+			csv := &operatorsv1alpha1.ClusterServiceVersion{}
+			csv.SetName(genName("csv-"))
+			csv.SetNamespace("default")
+			defer requireCreate(t, clientCtx, client, csv)()
+
+			owned := &corev1.ServiceAccount{}
+			owned.SetName(genName("sa-"))
+			owned.SetNamespace(csv.GetNamespace())
+			blockOwnerDeletion, isController := true, true
+			owned.SetOwnerReferences([]metav1.OwnerReference{
+				{
+					APIVersion:         operatorsv1alpha1.SchemeGroupVersion.String(),
+					Kind:               operatorsv1alpha1.ClusterServiceVersionKind,
+					Name:               csv.GetName(),
+					UID:                csv.GetUID(),
+					Controller:         &isController,
+					BlockOwnerDeletion: &blockOwnerDeletion,
 				},
+			})
+			defer requireCreate(t, clientCtx, client, owned)()
+
+			w, err := operatorClient.Watch(clientCtx, listOpts)
+			require.NoError(t, err)
+			defer w.Stop()
+
+			componentRefEventuallyExists(t, w, true, getReference(scheme, owned))
+		})
+
+		// Ensures that an Operator configured with the MatchingResources adoption strategy adopts exactly the
+		// GVK+name/namespace tuples declared in its strategy, regardless of labels or ownership.
+		t.Run("should adopt components via explicit matching resources", func(t *testing.T) {
+			t.Parallel()
+
+			cm := &corev1.ConfigMap{}
+			cm.SetName(genName("cm-"))
+			cm.SetNamespace("default")
+			defer requireCreate(t, clientCtx, client, cm)()
+
+			o := &operatorsv2alpha1.Operator{}
+			o.SetName(genName("o-"))
+
+			deco, err := operatorFactory.NewOperator(testobj.NamespacedName(o))
+			require.NoError(t, err)
+			require.NoError(t, deco.SetAdoptionStrategy(decorators.AdoptionStrategy{
+				Type: decorators.MatchingResourcesAdoptionStrategy,
+				MatchingResources: []decorators.MatchingResource{
+					{
+						GroupVersionKind: corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+						Namespace:        cm.GetNamespace(),
+						Name:             cm.GetName(),
+					},
+				},
+			}))
+			o.SetAnnotations(deco.GetAnnotations())
+			defer requireCreate(t, clientCtx, client, o)()
+
+			w, err := operatorClient.Watch(clientCtx, listOpts)
+			require.NoError(t, err)
+			defer w.Stop()
+
+			componentRefEventuallyExists(t, w, true, getReference(scheme, cm))
+		})
+
+		// Ensures that an Operator configured with the NamespaceScoped adoption strategy adopts every resource in
+		// its target namespace, without requiring the component label.
+		t.Run("should adopt all resources within its target namespaces", func(t *testing.T) {
+			t.Parallel()
+
+			ns := &corev1.Namespace{}
+			ns.SetName(genName("ns-"))
+			defer requireCreate(t, clientCtx, client, ns)()
+
+			o := &operatorsv2alpha1.Operator{}
+			o.SetName(genName("o-"))
+
+			deco, err := operatorFactory.NewOperator(testobj.NamespacedName(o))
+			require.NoError(t, err)
+			require.NoError(t, deco.SetAdoptionStrategy(decorators.AdoptionStrategy{
+				Type:       decorators.NamespaceScopedAdoptionStrategy,
+				Namespaces: []string{ns.GetName()},
+			}))
+			o.SetAnnotations(deco.GetAnnotations())
+			defer requireCreate(t, clientCtx, client, o)()
+
+			unlabeled := &corev1.ServiceAccount{}
+			unlabeled.SetName(genName("sa-"))
+			unlabeled.SetNamespace(ns.GetName())
+			defer requireCreate(t, clientCtx, client, unlabeled)()
+
+			w, err := operatorClient.Watch(clientCtx, listOpts)
+			require.NoError(t, err)
+			defer w.Stop()
+
+			componentRefEventuallyExists(t, w, true, getReference(scheme, unlabeled))
+		})
+	})
+
+	t.Run("when a subscription to a package exists", func(t *testing.T) {
+		t.Parallel()
+
+		// Subscribe to a package and await a successful install
+		ns := &corev1.Namespace{}
+		ns.SetName(genName("ns-"))
+		defer requireCreate(t, clientCtx, client, ns)()
+
+		// Default to AllNamespaces
+		og := &operatorsv1.OperatorGroup{}
+		og.SetNamespace(ns.GetName())
+		og.SetName(genName("og-"))
+		defer requireCreate(t, clientCtx, client, og)()
+
+		cs := &operatorsv1alpha1.CatalogSource{
+			Spec: operatorsv1alpha1.CatalogSourceSpec{
+				SourceType: operatorsv1alpha1.SourceTypeGrpc,
+				Image:      "quay.io/olmtest/single-bundle-index:1.0.0",
+			},
+		}
+		cs.SetNamespace(ns.GetName())
+		cs.SetName(genName("cs-"))
+		defer requireCreate(t, clientCtx, client, cs)()
+
+		sub := &operatorsv1alpha1.Subscription{
+			Spec: &operatorsv1alpha1.SubscriptionSpec{
+				CatalogSource:          cs.GetName(),
+				CatalogSourceNamespace: cs.GetNamespace(),
+				Package:                "kiali",
+				Channel:                "stable",
+				InstallPlanApproval:    operatorsv1alpha1.ApprovalAutomatic,
+			},
+		}
+		sub.SetNamespace(cs.GetNamespace())
+		sub.SetName(genName("sub-"))
+		defer requireCreate(t, clientCtx, client, sub)()
+
+		require.Eventuallyf(t, func() bool {
+			s := sub.DeepCopy()
+			if err := client.Get(clientCtx, testobj.NamespacedName(s), s); err != nil {
+				return false
 			}
-			sub.SetNamespace(cs.GetNamespace())
-			sub.SetName(genName("sub-"))
-			Eventually(func() error {
-				return client.Create(clientCtx, sub)
-			}).Should(Succeed())
-
-			Eventually(func() (operatorsv1alpha1.SubscriptionState, error) {
-				s := sub.DeepCopy()
-				if err := client.Get(clientCtx, testobj.NamespacedName(s), s); err != nil {
-					return operatorsv1alpha1.SubscriptionStateNone, err
-				}
+			return s.Status.State == operatorsv1alpha1.SubscriptionStateAtLatest
+		}, pollTimeout, pollInterval, "expected subscription %s to reach AtLatest", sub.GetName())
+
+		operator, err := operatorFactory.NewPackageOperator(sub.Spec.Package, sub.GetNamespace())
+		require.NoError(t, err)
+		operatorName := testobj.NamespacedName(operator)
 
-				return s.Status.State, nil
-			}).Should(BeEquivalentTo(operatorsv1alpha1.SubscriptionStateAtLatest))
+		t.Run("should automatically adopt components", func(t *testing.T) {
+			require.Eventuallyf(t, func() bool {
+				o := &operatorsv2alpha1.Operator{}
+				if err := client.Get(clientCtx, operatorName, o); err != nil {
+					return false
+				}
 
-			operator, err := operatorFactory.NewPackageOperator(sub.Spec.Package, sub.GetNamespace())
-			Expect(err).ToNot(HaveOccurred())
-			operatorName = testobj.NamespacedName(operator)
+				return referencesComponents(o, []*corev1.ObjectReference{
+					getReference(scheme, sub),
+					getReference(scheme, testobj.WithNamespacedName(
+						&types.NamespacedName{Namespace: sub.GetNamespace(), Name: "kiali-operator.v1.4.2"},
+						&operatorsv1alpha1.ClusterServiceVersion{},
+					)),
+					getReference(scheme, testobj.WithNamespacedName(
+						&types.NamespacedName{Namespace: sub.GetNamespace(), Name: "kiali-operator"},
+						&corev1.ServiceAccount{},
+					)),
+					getReference(scheme, testobj.WithName("kialis.kiali.io", &apiextensionsv1.CustomResourceDefinition{})),
+					getReference(scheme, testobj.WithName("monitoringdashboards.monitoring.kiali.io", &apiextensionsv1.CustomResourceDefinition{})),
+				})
+			}, pollTimeout, pollInterval, "expected operator %s to adopt the subscription's components", operatorName)
 		})
 
-		AfterEach(func() {
-			Eventually(func() error {
-				err := client.Delete(clientCtx, ns)
-				if apierrors.IsNotFound(err) {
-					return nil
+		// Ensures that flipping the Operator's management state (an annotation, see
+		// decorators.Operator.SetManagementState) to Unmanaged freezes status.components.refs, and flipping it back to
+		// Managed resumes reconciliation.
+		//
+		// Steps:
+		// 1. Wait for the Operator to adopt its components (as above)
+		// 2. Set the Operator's managementState to Unmanaged
+		// 3. Label an additional ServiceAccount with the Operator's component key
+		// 4. Ensure the new ServiceAccount's reference is NOT added to status.components.refs
+		// 5. Set the Operator's managementState back to Managed
+		// 6. Ensure the new ServiceAccount's reference eventually IS added to status.components.refs
+		t.Run("should freeze and unfreeze component refs across managementState transitions", func(t *testing.T) {
+			require.Eventuallyf(t, func() bool {
+				o := &operatorsv2alpha1.Operator{}
+				return client.Get(clientCtx, operatorName, o) == nil && o.Status.Components != nil
+			}, pollTimeout, pollInterval, "expected operator %s to have a components status", operatorName)
+
+			o := &operatorsv2alpha1.Operator{}
+			require.NoError(t, client.Get(clientCtx, operatorName, o))
+			deco, err := decorators.NewOperatorFromAPI(o)
+			require.NoError(t, err)
+			deco.SetManagementState(decorators.Unmanaged)
+			o.SetAnnotations(deco.GetAnnotations())
+			requireEventually(t, func() error { return client.Update(clientCtx, o) })
+
+			extra := &corev1.ServiceAccount{}
+			extra.SetName(genName("sa-"))
+			extra.SetNamespace(sub.GetNamespace())
+			extra.SetLabels(map[string]string{"operators.coreos.com/" + operatorName.Name: ""})
+			defer requireCreate(t, clientCtx, client, extra)()
+
+			require.Never(t, func() bool {
+				o := &operatorsv2alpha1.Operator{}
+				if err := client.Get(clientCtx, operatorName, o); err != nil {
+					return false
+				}
+				return referencesComponents(o, []*corev1.ObjectReference{getReference(scheme, extra)})
+			}, 10*time.Second, pollInterval, "expected component refs to stay frozen while Unmanaged")
+
+			o = &operatorsv2alpha1.Operator{}
+			require.NoError(t, client.Get(clientCtx, operatorName, o))
+			deco, err = decorators.NewOperatorFromAPI(o)
+			require.NoError(t, err)
+			deco.SetManagementState(decorators.Managed)
+			o.SetAnnotations(deco.GetAnnotations())
+			requireEventually(t, func() error { return client.Update(clientCtx, o) })
+
+			require.Eventuallyf(t, func() bool {
+				o := &operatorsv2alpha1.Operator{}
+				if err := client.Get(clientCtx, operatorName, o); err != nil {
+					return false
 				}
-				return err
-			}).Should(Succeed())
+				return referencesComponents(o, []*corev1.ObjectReference{getReference(scheme, extra)})
+			}, pollTimeout, pollInterval, "expected component refs to resume once Managed again")
 		})
 
-		It("should automatically adopt components", func() {
-			Eventually(func() (*operatorsv2alpha1.Operator, error) {
+		// Ensures that flipping managementState to Removed garbage-collects the Operator's adopted CRDs,
+		// ServiceAccounts, and CSVs, then deletes the Operator itself.
+		t.Run("should garbage-collect adopted components and delete itself when Removed", func(t *testing.T) {
+			require.Eventuallyf(t, func() bool {
 				o := &operatorsv2alpha1.Operator{}
-				err := client.Get(clientCtx, operatorName, o)
-				return o, err
-			}).Should(ReferenceComponents([]*corev1.ObjectReference{
-				getReference(scheme, sub),
-				getReference(scheme, testobj.WithNamespacedName(
-					&types.NamespacedName{Namespace: sub.GetNamespace(), Name: "kiali-operator.v1.4.2"},
-					&operatorsv1alpha1.ClusterServiceVersion{},
-				)),
-				getReference(scheme, testobj.WithNamespacedName(
-					&types.NamespacedName{Namespace: sub.GetNamespace(), Name: "kiali-operator"},
-					&corev1.ServiceAccount{},
-				)),
-				getReference(scheme, testobj.WithName("kialis.kiali.io", &apiextensionsv1.CustomResourceDefinition{})),
-				getReference(scheme, testobj.WithName("monitoringdashboards.monitoring.kiali.io", &apiextensionsv1.CustomResourceDefinition{})),
-			}))
+				return client.Get(clientCtx, operatorName, o) == nil && o.Status.Components != nil
+			}, pollTimeout, pollInterval, "expected operator %s to have a components status", operatorName)
+
+			o := &operatorsv2alpha1.Operator{}
+			require.NoError(t, client.Get(clientCtx, operatorName, o))
+			deco, err := decorators.NewOperatorFromAPI(o)
+			require.NoError(t, err)
+			deco.SetManagementState(decorators.Removed)
+			o.SetAnnotations(deco.GetAnnotations())
+			requireEventually(t, func() error { return client.Update(clientCtx, o) })
+
+			csv := testobj.WithNamespacedName(
+				&types.NamespacedName{Namespace: sub.GetNamespace(), Name: "kiali-operator.v1.4.2"},
+				&operatorsv1alpha1.ClusterServiceVersion{},
+			)
+			sa := testobj.WithNamespacedName(
+				&types.NamespacedName{Namespace: sub.GetNamespace(), Name: "kiali-operator"},
+				&corev1.ServiceAccount{},
+			)
+			crd := testobj.WithName("kialis.kiali.io", &apiextensionsv1.CustomResourceDefinition{})
+
+			require.Eventuallyf(t, func() bool {
+				return apierrors.IsNotFound(client.Get(clientCtx, testobj.NamespacedName(csv), csv)) &&
+					apierrors.IsNotFound(client.Get(clientCtx, testobj.NamespacedName(sa), sa)) &&
+					apierrors.IsNotFound(client.Get(clientCtx, testobj.NamespacedName(crd), crd))
+			}, pollTimeout, pollInterval, "expected operator %s's adopted CRDs, ServiceAccounts, and CSVs to be garbage-collected", operatorName)
+
+			require.Eventuallyf(t, func() bool {
+				return apierrors.IsNotFound(client.Get(clientCtx, operatorName, &operatorsv2alpha1.Operator{}))
+			}, pollTimeout, pollInterval, "expected operator %s to be deleted", operatorName)
 		})
 	})
+}
+
+// requireEventually polls fn until it returns nil, failing the test if pollTimeout elapses first.
+func requireEventually(t *testing.T, fn func() error) {
+	t.Helper()
+
+	var lastErr error
+	for deadline := time.Now().Add(pollTimeout); time.Now().Before(deadline); time.Sleep(pollInterval) {
+		if lastErr = fn(); lastErr == nil {
+			return
+		}
+	}
+	t.Fatalf("condition never succeeded, last error: %v", lastErr)
+}
+
+// requireCreate creates obj, failing the test if creation never succeeds within pollTimeout, and returns a func
+// that deletes it. Callers typically `defer requireCreate(t, ctx, cl, obj)()`.
+func requireCreate(t *testing.T, clientCtx context.Context, cl controllerclient.Client, obj controllerclient.Object) func() {
+	t.Helper()
+
+	requireEventually(t, func() error {
+		return cl.Create(clientCtx, obj)
+	})
 
-})
+	return func() {
+		requireEventually(t, func() error {
+			err := cl.Delete(clientCtx, obj)
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		})
+	}
+}
 
 func getReference(scheme *runtime.Scheme, obj runtime.Object) *corev1.ObjectReference {
 	ref, err := reference.GetReference(scheme, obj)
@@ -316,8 +608,10 @@ func getReference(scheme *runtime.Scheme, obj runtime.Object) *corev1.ObjectRefe
 	return ref
 }
 
-func componentRefEventuallyExists(w watch.Interface, exists bool, ref *corev1.ObjectReference) {
-	deadline, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+func componentRefEventuallyExists(t *testing.T, w watch.Interface, exists bool, ref *corev1.ObjectReference) {
+	t.Helper()
+
+	deadline, cancel := context.WithTimeout(context.Background(), pollTimeout)
 	defer cancel()
 
 	awaitPredicates(deadline, w, operatorPredicate(func(op *operatorsv2alpha1.Operator) bool {
@@ -346,48 +640,54 @@ func operatorPredicate(fn func(*operatorsv2alpha1.Operator) bool) predicateFunc
 	}
 }
 
-type OperatorMatcher struct {
-	matches func(*operatorsv2alpha1.Operator) (bool, error)
-	name    string
-}
+// referencesComponents reports whether operator's status.components.refs contains every ref in refs.
+func referencesComponents(operator *operatorsv2alpha1.Operator, refs []*corev1.ObjectReference) bool {
+	if operator.Status.Components == nil {
+		return false
+	}
 
-func (o OperatorMatcher) Match(actual interface{}) (bool, error) {
-	operator, ok := actual.(*operatorsv2alpha1.Operator)
-	if !ok {
-		return false, fmt.Errorf("OperatorMatcher expects Operator (got %T)", actual)
+	actual := map[corev1.ObjectReference]struct{}{}
+	for _, ref := range operator.Status.Components.Refs {
+		actual[*ref.ObjectReference] = struct{}{}
 	}
 
-	return o.matches(operator)
-}
+	for _, ref := range refs {
+		if _, ok := actual[*ref]; !ok {
+			return false
+		}
+	}
 
-func (o OperatorMatcher) String() string {
-	return o.name
+	return true
 }
 
-func (o OperatorMatcher) FailureMessage(actual interface{}) string {
-	return format.Message(actual, "to satisfy", o)
-}
+// driftedConditionStatus returns the status of op's Drifted condition, or empty string if it hasn't been set yet.
+func driftedConditionStatus(op *operatorsv2alpha1.Operator) metav1.ConditionStatus {
+	for _, c := range op.Status.Conditions {
+		if c.Type == decorators.DriftedConditionType {
+			return c.Status
+		}
+	}
 
-func (o OperatorMatcher) NegatedFailureMessage(actual interface{}) string {
-	return format.Message(actual, "not to satisfy", o)
+	return ""
 }
 
-func ReferenceComponents(refs []*corev1.ObjectReference) gomegatypes.GomegaMatcher {
-	return &OperatorMatcher{
-		matches: func(operator *operatorsv2alpha1.Operator) (bool, error) {
-			actual := map[corev1.ObjectReference]struct{}{}
-			for _, ref := range operator.Status.Components.Refs {
-				actual[*ref.ObjectReference] = struct{}{}
-			}
-
-			for _, ref := range refs {
-				if _, ok := actual[*ref]; !ok {
-					return false, nil
-				}
-			}
+// componentChangeEventRecorded returns true if an Event exists against o naming change for ref.
+func componentChangeEventRecorded(ctx context.Context, cl controllerclient.Client, o *operatorsv2alpha1.Operator, change decorators.ComponentChangeType, ref *corev1.ObjectReference) bool {
+	events := &corev1.EventList{}
+	if err := cl.List(ctx, events); err != nil {
+		return false
+	}
 
-			return true, nil
-		},
-		name: fmt.Sprintf("ReferenceComponents(%v)", refs),
+	reason := decorators.ComponentChangeEventReason(change)
+	message := decorators.ComponentChangeEventMessage(*ref)
+	for _, e := range events.Items {
+		if e.InvolvedObject.Kind != "Operator" || e.InvolvedObject.Name != o.GetName() {
+			continue
+		}
+		if e.Reason == reason && e.Message == message {
+			return true
+		}
 	}
+
+	return false
 }